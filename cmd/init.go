@@ -0,0 +1,73 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package cmd
+
+import (
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/projectInit"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initSilent  bool
+	initOffline bool
+	initVars    []string
+	initURL     string
+	initFile    string
+)
+
+// initCmd initializes a new Gauge project from a registered template, a
+// template URL/git URL or a local template zip file, e.g.
+//
+//	gauge init java
+//	gauge init java --var packageName=com.example
+//	gauge init java --offline
+//	gauge init --url git+https://github.com/getgauge/template-java.git@v1.2.0
+//	gauge init --file ./my-template.zip
+var initCmd = &cobra.Command{
+	Use:     "init [<template name>]",
+	Short:   "Initialize a new Gauge project",
+	Example: "  gauge init java\n  gauge init java --var packageName=com.example --offline",
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, kv := range initVars {
+			idx := indexOfEquals(kv)
+			if idx < 0 {
+				logger.Fatalf(true, "Invalid --var '%s'. Expected the form name=value.", kv)
+			}
+			projectInit.Vars[kv[:idx]] = kv[idx+1:]
+		}
+		switch {
+		case initURL != "":
+			projectInit.FromURL(initURL, initSilent)
+		case initFile != "":
+			projectInit.FromZipFile(initFile, initSilent)
+		case len(args) == 1:
+			projectInit.FromTemplate(args[0], initSilent, initOffline)
+		default:
+			logger.Fatalf(true, "'gauge init' needs a template name, or a --url/--file to initialize from.")
+		}
+	},
+}
+
+func indexOfEquals(kv string) int {
+	for i := 0; i < len(kv); i++ {
+		if kv[i] == '=' {
+			return i
+		}
+	}
+	return -1
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initSilent, "silent", false, "Do not show any output while initializing a project")
+	initCmd.Flags().BoolVar(&initOffline, "offline", false, "Initialize from the newest cached copy of the template instead of downloading it")
+	initCmd.Flags().StringArrayVar(&initVars, "var", nil, "Set a template variable, e.g. --var name=value. Can be repeated.")
+	initCmd.Flags().StringVar(&initURL, "url", "", "Initialize from a template at the given URL")
+	initCmd.Flags().StringVar(&initFile, "file", "", "Initialize from a local template zip file")
+	GaugeCmd.AddCommand(initCmd)
+}
@@ -0,0 +1,24 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+// Package cmd wires Gauge's command line flags and subcommands to the
+// library packages (projectInit, template, ...) that implement them.
+package cmd
+
+import "github.com/spf13/cobra"
+
+// GaugeCmd is the root Gauge command. Every subcommand attaches to it in its
+// own init().
+var GaugeCmd = &cobra.Command{
+	Use:   "gauge",
+	Short: "Gauge is a light weight cross-platform test automation tool",
+}
+
+// Execute runs the Gauge command line, dispatching to whichever subcommand
+// was invoked.
+func Execute() error {
+	return GaugeCmd.Execute()
+}
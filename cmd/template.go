@@ -0,0 +1,80 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	templateMachineReadable bool
+	templateSHA256          string
+	templatePubKey          string
+)
+
+// templateCmd registers (or lists) Gauge templates, e.g.
+//
+//	gauge template java https://github.com/getgauge/template-java/releases/latest/download/java.zip
+//	gauge template java https://.../java.zip --sha256 <checksum> --pubkey <minisign key>
+//	gauge template list
+var templateCmd = &cobra.Command{
+	Use:     "template [<template name> <template url>]",
+	Short:   "Add or list Gauge templates",
+	Example: "  gauge template java https://github.com/getgauge/template-java/releases/latest/download/java.zip",
+	Args:    cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return printTemplateList()
+		}
+		if len(args) != 2 {
+			return fmt.Errorf("'gauge template' needs both a template name and a template URL")
+		}
+		if templateSHA256 != "" || templatePubKey != "" {
+			return template.UpdateWithVerification(args[0], args[1], templateSHA256, templatePubKey)
+		}
+		return template.Update(args[0], args[1])
+	},
+}
+
+var templateVerifyCmd = &cobra.Command{
+	Use:   "verify <template name>",
+	Short: "Download a template and verify it against its pinned checksum/public key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return template.Verify(args[0])
+	},
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the currently configured Gauge templates",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printTemplateList()
+	},
+}
+
+func printTemplateList() error {
+	out, err := template.List(templateMachineReadable)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
+
+func init() {
+	templateCmd.Flags().BoolVarP(&templateMachineReadable, "machine-readable", "m", false, "Print the output in machine readable format")
+	templateCmd.Flags().StringVar(&templateSHA256, "sha256", "", "Pin a SHA-256 checksum the template archive must match before it is used")
+	templateCmd.Flags().StringVar(&templatePubKey, "pubkey", "", "Pin a minisign public key the template archive's signature must verify against")
+	templateListCmd.Flags().BoolVarP(&templateMachineReadable, "machine-readable", "m", false, "Print the output in machine readable format")
+	templateCmd.AddCommand(templateListCmd, templateVerifyCmd)
+	GaugeCmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,41 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package cmd
+
+import (
+	"github.com/getgauge/gauge/template"
+	"github.com/spf13/cobra"
+)
+
+// templateSaveCmd caches a local template directory so it can be used by
+// `gauge init <name>` (with or without --offline) without downloading it,
+// e.g. to pre-populate an air-gapped CI runner's cache.
+var templateSaveCmd = &cobra.Command{
+	Use:     "save <template name> <path>",
+	Short:   "Save a local directory as a cached Gauge template",
+	Example: "  gauge template save java ./my-java-template",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return template.Save(args[0], args[1])
+	},
+}
+
+// templateRestoreCmd re-downloads every registered zip template that is not
+// already cached, so that a subsequent `gauge init <name> --offline` has
+// something to work with. Git and file:// templates are skipped.
+var templateRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Download and cache every registered zip template that isn't already cached",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return template.Restore()
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(templateSaveCmd, templateRestoreCmd)
+}
@@ -0,0 +1,69 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/template"
+	"github.com/spf13/cobra"
+)
+
+// templateRegistryCmd is the parent for the `gauge template registry ...`
+// subcommands that manage the registries templates are looked up from.
+var templateRegistryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage Gauge template registries",
+}
+
+var templateRegistryAddCmd = &cobra.Command{
+	Use:     "add <registry name> <registry url>",
+	Short:   "Register a template registry",
+	Example: "  gauge template registry add acme https://templates.acme.example/index.json",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return template.RegistryAdd(args[0], args[1])
+	},
+}
+
+var templateRegistryRemoveCmd = &cobra.Command{
+	Use:   "remove <registry name>",
+	Short: "Unregister a template registry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return template.RegistryRemove(args[0])
+	},
+}
+
+var templateRegistryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured template registries",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := template.RegistryList(templateMachineReadable)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	},
+}
+
+var templateRegistryRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Refresh the cached index of every configured template registry",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return template.RegistryRefresh()
+	},
+}
+
+func init() {
+	templateRegistryListCmd.Flags().BoolVarP(&templateMachineReadable, "machine-readable", "m", false, "Print the output in machine readable format")
+	templateRegistryCmd.AddCommand(templateRegistryAddCmd, templateRegistryRemoveCmd, templateRegistryListCmd, templateRegistryRefreshCmd)
+	templateCmd.AddCommand(templateRegistryCmd)
+}
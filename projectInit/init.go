@@ -28,6 +28,8 @@ const (
 	gitignoreFileName = ".gitignore"
 	metadataFileName  = "metadata.json"
 	https             = "https"
+	fileScheme        = "file"
+	fileURLPrefix     = "file://"
 )
 
 type templateMetadata struct {
@@ -36,25 +38,160 @@ type templateMetadata struct {
 	Version        string
 	PostInstallCmd string
 	PostInstallMsg string
+	Variables      []TemplateVar
 }
 
-func initializeTemplate(templateUrl string) error {
+// initializeTemplate downloads (or clones) templateUrl and initializes the
+// current project from it. When name is non-empty (i.e. the template was
+// resolved from the registered template name rather than a raw URL or local
+// zip), the fetched contents are also saved to the local template cache so a
+// later `gauge init <name> --offline` can reuse them.
+//
+// A "file://" templateUrl (as template.Save writes for a locally registered
+// template) is read directly off disk instead of being downloaded: it is
+// already a trusted local copy, so no network fetch or archive verification
+// applies to it.
+func initializeTemplate(name, templateUrl string, silent bool) error {
+	if strings.HasPrefix(templateUrl, fileURLPrefix) {
+		return initializeLocalTemplate(name, templateUrl, silent)
+	}
 	tempDir := common.GetTempDir()
 	defer util.Remove(tempDir)
 	logger.Infof(true, "Initializing template from %s", templateUrl)
-	unzippedTemplate, err := util.DownloadAndUnzip(templateUrl, tempDir)
+	var unzipped string
+	var err error
+	if template.IsGitURL(templateUrl) {
+		unzipped, err = cloneGitTemplate(templateUrl, tempDir)
+		if err != nil {
+			return fmt.Errorf("%w. Please make sure that this is a valid Gauge template git repository or there are no problems with the network connection", err)
+		}
+	} else {
+		unzipped, err = util.DownloadAndUnzip(templateUrl, tempDir)
+		if err != nil {
+			return fmt.Errorf("%w. Please sure that this is a valid Gauge template URI or there are no problems with the network connection", err)
+		}
+		archive, archiveErr := template.LocateArchive(tempDir)
+		if archiveErr != nil {
+			if template.IsVerificationPinned(templateUrl) {
+				return fmt.Errorf("a checksum/public key is pinned for this template but the downloaded archive could not be located to verify it: %w", archiveErr)
+			}
+		} else if err := template.VerifyArchive(templateUrl, archive); err != nil {
+			return err
+		}
+	}
+	templateDir, err := getTemplateDir(unzipped)
 	if err != nil {
-		return fmt.Errorf("%w. Please sure that this is a valid Gauge template URI or there are no problems with the network connection", err)
+		return fmt.Errorf("failed to copy template. The dir %s does not contain required files. %w", unzipped, err)
 	}
-	return copyTemplateContents(unzippedTemplate)
+	if name != "" {
+		if err := template.PopulateCache(name, templateUrl, templateDir); err != nil {
+			logger.Debugf(true, "Failed to cache template '%s': %s", name, err.Error())
+		}
+	}
+	return finishInit(templateDir, silent)
 }
 
-func copyTemplateContents(unzippedTemplate string) error {
-	wd := config.ProjectRoot
+// initializeLocalTemplate initializes the current project from the local
+// directory a "file://" templateUrl points at. Unlike initializeTemplate's
+// HTTP path, nothing is downloaded. When name is non-empty the directory is
+// also copied into the template cache, under an entry keyed by a hash of the
+// file:// templateUrl; for a template registered by template.Save this is a
+// second entry beside the one Save wrote.
+func initializeLocalTemplate(name, templateUrl string, silent bool) error {
+	localDir := strings.TrimPrefix(templateUrl, fileURLPrefix)
+	templateDir, err := getTemplateDir(localDir)
+	if err != nil {
+		return fmt.Errorf("failed to copy template. The dir %s does not contain required files. %w", localDir, err)
+	}
+	if name != "" {
+		if err := template.PopulateCache(name, templateUrl, templateDir); err != nil {
+			logger.Debugf(true, "Failed to cache template '%s': %s", name, err.Error())
+		}
+	}
+	return finishInit(templateDir, silent)
+}
+
+// cloneGitTemplate shallow-clones a git template repository into tempDir,
+// checking out ref (a branch, tag or commit SHA) when one is given. With no
+// ref the repo's default branch is used.
+func cloneGitTemplate(templateUrl, tempDir string) (string, error) {
+	cloneURL, ref := template.ParseGitURL(templateUrl)
+	dest := filepath.Join(tempDir, "repo")
+	args := []string{"git", "clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, cloneURL, dest)
+	logger.Debugf(true, "Cloning git template %s", cloneURL)
+	cmd, err := common.ExecuteSystemCommand(args, tempDir, os.Stdout, os.Stderr)
+	if err == nil {
+		err = cmd.Wait()
+	}
+	if err != nil && ref != "" {
+		// ref may be a commit SHA rather than a branch or tag, which --branch cannot shallow-clone.
+		// Fall back to a full clone followed by an explicit checkout.
+		util.Remove(dest)
+		cloneCmd, cErr := common.ExecuteSystemCommand([]string{"git", "clone", cloneURL, dest}, tempDir, os.Stdout, os.Stderr)
+		if cErr != nil {
+			return "", err
+		}
+		if cErr = cloneCmd.Wait(); cErr != nil {
+			return "", err
+		}
+		checkoutCmd, cErr := common.ExecuteSystemCommand([]string{"git", "checkout", ref}, dest, os.Stdout, os.Stderr)
+		if cErr != nil {
+			return "", cErr
+		}
+		if cErr = checkoutCmd.Wait(); cErr != nil {
+			return "", cErr
+		}
+		return dest, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+func copyTemplateContents(unzippedTemplate string, silent bool) error {
 	templateDir, err := getTemplateDir(unzippedTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to copy template. The dir %s does not contain required files. %w", unzippedTemplate, err)
 	}
+	return finishInit(templateDir, silent)
+}
+
+// finishInit resolves template variables, copies templateDir into the
+// current project and runs the template's post-install command. templateDir
+// must already point at the directory containing the template's manifest.
+// Variables are expanded in a temporary copy; templateDir is left unchanged.
+func finishInit(templateDir string, silent bool) error {
+	wd := config.ProjectRoot
+	metadata, err := readTemplateMetadata(templateDir)
+	if err != nil {
+		return err
+	}
+
+	var vars map[string]string
+	if len(metadata.Variables) > 0 {
+		vars, err = resolveVariables(metadata.Variables, silent)
+		if err != nil {
+			return err
+		}
+		// Expand into a scratch copy so templateDir itself (which may be a
+		// template cache entry) keeps its placeholders for later runs.
+		scratchDir := common.GetTempDir()
+		defer util.Remove(scratchDir)
+		expandDir := filepath.Join(scratchDir, filepath.Base(templateDir))
+		if _, err := common.MirrorDir(templateDir, expandDir); err != nil {
+			return fmt.Errorf("failed to copy template for variable expansion: %w", err)
+		}
+		templateDir = expandDir
+		if err := expandTemplateVariables(templateDir, vars); err != nil {
+			return fmt.Errorf("failed to expand template variables: %w", err)
+		}
+	}
+
 	if common.FileExists(gitignoreFileName) {
 		templateGitIgnore := filepath.Join(templateDir, gitignoreFileName)
 		if err := common.AppendToFile(gitignoreFileName, templateGitIgnore); err != nil {
@@ -69,15 +206,9 @@ func copyTemplateContents(unzippedTemplate string) error {
 	}
 
 	metadataFile := filepath.Join(wd, metadataFileName)
-	metadataContents, err := common.ReadFileContents(metadataFile)
-	if err != nil {
-		return fmt.Errorf("Failed to read file contents of %s: %s", metadataFile, err.Error())
-	}
 
-	metadata := &templateMetadata{}
-	err = json.Unmarshal([]byte(metadataContents), metadata)
-	if err != nil {
-		return err
+	for k, v := range vars {
+		os.Setenv(k, v)
 	}
 
 	if metadata.PostInstallCmd != "" {
@@ -101,6 +232,19 @@ func copyTemplateContents(unzippedTemplate string) error {
 	return nil
 }
 
+func readTemplateMetadata(templateDir string) (*templateMetadata, error) {
+	metadataFile := filepath.Join(templateDir, metadataFileName)
+	metadataContents, err := common.ReadFileContents(metadataFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read file contents of %s: %s", metadataFile, err.Error())
+	}
+	metadata := &templateMetadata{}
+	if err := json.Unmarshal([]byte(metadataContents), metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
 func getTemplateDir(unzippedTemplate string) (templateDir string, err error) {
 	err = filepath.Walk(unzippedTemplate, func(path string, info os.FileInfo, err error) error {
 		if err == nil && info.IsDir() && common.FileExists(filepath.Join(path, common.ManifestFile)) {
@@ -131,15 +275,32 @@ func installRunner(silent bool) {
 	}
 }
 
-// FromTemplate initializes a Gauge project with specified template
-func FromTemplate(templateName string, silent bool) {
+// FromTemplate initializes a Gauge project with specified template. When
+// offline is true, the template is not downloaded: the newest cached copy of
+// templateName is used instead, and initialization fails if none exists.
+// Installing a missing language runner afterwards still uses the network.
+func FromTemplate(templateName string, silent, offline bool) {
 	validateDirectory()
+	if offline {
+		cacheDir, ok, err := template.CachedCopy(templateName)
+		if err != nil {
+			logger.Fatalf(true, fmt.Errorf("failed to initialize project. %w", err).Error())
+		}
+		if !ok {
+			logger.Fatalf(true, "No cached copy of template '%s' was found. Run 'gauge init %s' online at least once before using --offline.", templateName, templateName)
+		}
+		if err := copyTemplateContents(cacheDir, silent); err != nil {
+			logger.Fatalf(true, fmt.Errorf("failed to initialize project. %w", err).Error())
+		}
+		installRunner(silent)
+		return
+	}
 	templateURL, err := template.Get(templateName)
 	if err != nil {
 		logger.Fatalf(true, fmt.Errorf("failed to initialize project. %w", err).Error())
 	}
 	checkURL(templateURL)
-	if err := initializeTemplate(templateURL); err != nil {
+	if err := initializeTemplate(templateName, templateURL, silent); err != nil {
 		logger.Fatalf(true, fmt.Errorf("failed to initialize project. %w", err).Error())
 	}
 	installRunner(silent)
@@ -149,7 +310,7 @@ func FromTemplate(templateName string, silent bool) {
 func FromURL(templateURL string, silent bool) {
 	validateDirectory()
 	checkURL(templateURL)
-	if err := initializeTemplate(templateURL); err != nil {
+	if err := initializeTemplate("", templateURL, silent); err != nil {
 		logger.Fatalf(true, "Failed to initialize project. %s", err.Error())
 	}
 	installRunner(silent)
@@ -164,7 +325,7 @@ func FromZipFile(templateFile string, silent bool) {
 	if err != nil {
 		logger.Fatalf(true, "Failed to initialize project. %s", err.Error())
 	}
-	err = copyTemplateContents(unzippedTemplateDir)
+	err = copyTemplateContents(unzippedTemplateDir, silent)
 	if err != nil {
 		logger.Fatalf(true, "Failed to initialize project. %s", err.Error())
 	}
@@ -183,10 +344,19 @@ func validateDirectory() {
 }
 
 func checkURL(templateURL string) {
-	u, err := url.ParseRequestURI(templateURL)
+	rawURL := templateURL
+	if template.IsGitURL(templateURL) {
+		rawURL, _ = template.ParseGitURL(templateURL)
+	}
+	u, err := url.ParseRequestURI(rawURL)
 	if err != nil {
 		logger.Fatalf(true, "Failed to parse template URL '%s'. The template location must be a valid (https) URI", templateURL)
 	}
+	if u.Scheme == fileScheme {
+		// A local path registered via template.Save is already on this
+		// machine's disk; it is never fetched over the network.
+		return
+	}
 	if u.Scheme != https && !config.AllowInsecureDownload() {
 		logger.Fatalf(true, "The url '%s' in not secure and 'allow_insecure_download' is set to false.\n"+
 			"To allow insecure downloads set 'allow_insecure_download' configuration to true.\n"+
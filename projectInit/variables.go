@@ -0,0 +1,186 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package projectInit
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// TemplateVar describes a single scaffolding parameter declared by a
+// template's metadata.json, e.g. the project name or a target framework
+// version.
+type TemplateVar struct {
+	Name    string
+	Prompt  string
+	Default string
+	Regex   string
+	EnvVar  string
+}
+
+// Vars holds `--var name=value` overrides supplied on the command line,
+// keyed by variable name. The cmd package populates this before calling
+// FromTemplate, FromURL or FromZipFile.
+var Vars = map[string]string{}
+
+// resolveVariables determines a value for every variable a template
+// declares: a --var override takes precedence, then the variable's EnvVar,
+// then (in silent/non-interactive mode) its Default, and otherwise an
+// interactive prompt.
+func resolveVariables(vars []TemplateVar, silent bool) (map[string]string, error) {
+	resolved := make(map[string]string, len(vars))
+	for _, v := range vars {
+		value, err := resolveVariable(v, silent)
+		if err != nil {
+			return nil, err
+		}
+		resolved[v.Name] = value
+	}
+	return resolved, nil
+}
+
+func resolveVariable(v TemplateVar, silent bool) (string, error) {
+	if value, ok := Vars[v.Name]; ok && value != "" {
+		return checkVariable(v, value)
+	}
+	if v.EnvVar != "" {
+		if value := os.Getenv(v.EnvVar); value != "" {
+			return checkVariable(v, value)
+		}
+	}
+	if silent {
+		return checkVariable(v, v.Default)
+	}
+	return promptVariable(v)
+}
+
+func promptVariable(v TemplateVar) (string, error) {
+	prompt := v.Prompt
+	if prompt == "" {
+		prompt = v.Name
+	}
+	if v.Default != "" {
+		fmt.Printf("%s [%s]: ", prompt, v.Default)
+	} else {
+		fmt.Printf("%s: ", prompt)
+	}
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	value := strings.TrimSpace(line)
+	if value == "" {
+		value = v.Default
+	}
+	return checkVariable(v, value)
+}
+
+func checkVariable(v TemplateVar, value string) (string, error) {
+	if v.Regex == "" {
+		return value, nil
+	}
+	re, err := regexp.Compile(v.Regex)
+	if err != nil {
+		return "", fmt.Errorf("invalid validation regex for template variable '%s': %w", v.Name, err)
+	}
+	if !re.MatchString(value) {
+		return "", fmt.Errorf("value '%s' for template variable '%s' does not match %s", value, v.Name, v.Regex)
+	}
+	return value, nil
+}
+
+// binarySniffLength is how many leading bytes of a file are inspected to
+// decide whether it is binary, mirroring the heuristic net/http.DetectContentType
+// and similar tools use (the presence of a NUL byte).
+const binarySniffLength = 8000
+
+// isBinary reports whether data looks like a binary file, by checking for a
+// NUL byte among its first binarySniffLength bytes.
+func isBinary(data []byte) bool {
+	n := len(data)
+	if n > binarySniffLength {
+		n = binarySniffLength
+	}
+	return bytes.IndexByte(data[:n], 0) != -1
+}
+
+// expandText parses text as a Go text/template and executes it against
+// vars. name only identifies text in a parse/execution error; it is not
+// written anywhere. A variable referenced in text but not declared in vars
+// expands to the empty string (Option "missingkey=zero" against a
+// map[string]string) rather than failing, so a file that only uses some of
+// a template's declared variables still expands cleanly.
+func expandText(name, text string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// expandTemplateVariables expands "{{.VarName}}" placeholders for declared
+// template variables, resolved against vars using Go's text/template
+// engine, in every text file's contents and in every file/directory name
+// under root. Binary files are left untouched. Names are renamed
+// depth-first so renaming a parent directory never invalidates a path
+// already queued for renaming below it.
+func expandTemplateVariables(root string, vars map[string]string) error {
+	var paths []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		if !info.IsDir() {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if !isBinary(data) {
+				expanded, err := expandText(path, string(data), vars)
+				if err != nil {
+					return fmt.Errorf("failed to expand template variables in %s: %w", path, err)
+				}
+				if expanded != string(data) {
+					if err := os.WriteFile(path, []byte(expanded), info.Mode()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := len(paths) - 1; i >= 0; i-- {
+		path := paths[i]
+		name := filepath.Base(path)
+		expandedName, err := expandText(path, name, vars)
+		if err != nil {
+			return fmt.Errorf("failed to expand template variables in name of %s: %w", path, err)
+		}
+		if expandedName == name {
+			continue
+		}
+		if err := os.Rename(path, filepath.Join(filepath.Dir(path), expandedName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package projectInit
+
+import "testing"
+
+func TestExpandTextSubstitutesDeclaredVariables(t *testing.T) {
+	vars := map[string]string{"packageName": "com.example"}
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "declared variable",
+			content: "package {{.packageName}};",
+			want:    "package com.example;",
+		},
+		{
+			name:    "undeclared variable expands to empty string",
+			content: "{{.missing}}value",
+			want:    "value",
+		},
+		{
+			name:    "text with no template actions is unchanged",
+			content: "echo $HOME/$1 \"$@\"",
+			want:    "echo $HOME/$1 \"$@\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandText(tt.name, tt.content, vars)
+			if err != nil {
+				t.Fatalf("expandText(%q) returned an unexpected error: %v", tt.content, err)
+			}
+			if got != tt.want {
+				t.Errorf("expandText(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTextErrorsOnMalformedTemplate(t *testing.T) {
+	if _, err := expandText("broken", "{{.packageName", nil); err == nil {
+		t.Error("expected an error for malformed template syntax, got nil")
+	}
+}
+
+func TestIsBinaryDetectsNULByte(t *testing.T) {
+	if isBinary([]byte("just some plain text")) {
+		t.Error("plain text should not be detected as binary")
+	}
+	if !isBinary([]byte("png\x00header\x01\x02")) {
+		t.Error("content with a NUL byte should be detected as binary")
+	}
+}
@@ -0,0 +1,192 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/util"
+)
+
+const cacheDirName = "templates"
+const fileURLScheme = "file://"
+
+func cacheRoot() (string, error) {
+	home, err := common.GetGaugeHomeDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, cacheDirName), nil
+}
+
+func cacheDirFor(name, version string) (string, error) {
+	if err := validResourceName("template", name); err != nil {
+		return "", err
+	}
+	root, err := cacheRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, fmt.Sprintf("%s@%s", name, version)), nil
+}
+
+// validResourceName rejects names that could escape a directory they are
+// joined into as a path segment, e.g. via a ".." segment or a path
+// separator. kind only identifies the resource (e.g. "template" or
+// "registry") in the returned error. Callers of cacheDirFor use the
+// resulting path with os.RemoveAll, so an unsanitized name would otherwise
+// let a crafted or mistaken name delete arbitrary directories outside
+// $GAUGE_HOME.
+func validResourceName(kind, name string) error {
+	if name == "" || name == "." || name == ".." ||
+		strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("'%s' is not a valid %s name", name, kind)
+	}
+	return nil
+}
+
+// contentVersion derives a short, stable cache-directory suffix from a
+// template's resolved source location.
+func contentVersion(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// PopulateCache copies a fetched template's contents (templateDir), before
+// any template variables are expanded in them, into the persistent local cache, keyed by name and a hash of
+// resolvedURL, so it can be reused later with --offline.
+func PopulateCache(name, resolvedURL, templateDir string) error {
+	dest, err := cacheDirFor(name, contentVersion(resolvedURL))
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	if _, err := common.MirrorDir(templateDir, dest); err != nil {
+		return fmt.Errorf("failed to cache template '%s': %w", name, err)
+	}
+	return nil
+}
+
+// CachedCopy returns the most recently populated cache directory for name,
+// if one exists.
+func CachedCopy(name string) (string, bool, error) {
+	root, err := cacheRoot()
+	if err != nil {
+		return "", false, err
+	}
+	matches, err := filepath.Glob(filepath.Join(root, name+"@*"))
+	if err != nil {
+		return "", false, err
+	}
+	if len(matches) == 0 {
+		return "", false, nil
+	}
+	newest := matches[0]
+	newestModTime := cacheModTime(newest)
+	for _, m := range matches[1:] {
+		if t := cacheModTime(m); t.After(newestModTime) {
+			newest, newestModTime = m, t
+		}
+	}
+	return newest, true, nil
+}
+
+// HasCachedCopy reports whether a template has at least one cached copy.
+func HasCachedCopy(name string) bool {
+	_, ok, err := CachedCopy(name)
+	return err == nil && ok
+}
+
+func cacheModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// Save registers a local directory as a named template: its contents are
+// copied into the persistent template cache and a file:// entry pointing at
+// the cached copy is written to template.properties, so the template
+// resolves like any other, both with --offline and with a plain
+// `gauge init <name>` (projectInit reads a file:// template straight off
+// disk instead of trying to download it).
+func Save(name, localPath string) error {
+	abs, err := filepath.Abs(localPath)
+	if err != nil {
+		return err
+	}
+	if !common.DirExists(abs) {
+		return fmt.Errorf("'%s' is not a directory", localPath)
+	}
+	dest, err := cacheDirFor(name, contentVersion(abs))
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	if _, err := common.MirrorDir(abs, dest); err != nil {
+		return fmt.Errorf("failed to save template '%s': %w", name, err)
+	}
+	return Update(name, fileURLScheme+dest)
+}
+
+// Restore re-downloads every template registered in template.properties
+// whose cache entry is missing. Templates sourced from git or already
+// pointing at a local file:// cache entry are skipped.
+func Restore() error {
+	t, err := mergeTemplates()
+	if err != nil {
+		return err
+	}
+	for _, name := range t.names {
+		templateURL := t.t[name].Value
+		if IsGitURL(templateURL) || strings.HasPrefix(templateURL, fileURLScheme) {
+			continue
+		}
+		if HasCachedCopy(name) {
+			continue
+		}
+		if err := restoreTemplate(name, templateURL); err != nil {
+			return fmt.Errorf("failed to restore template '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+func restoreTemplate(name, templateURL string) error {
+	tempDir := common.GetTempDir()
+	defer util.Remove(tempDir)
+	unzipped, err := util.DownloadAndUnzip(templateURL, tempDir)
+	if err != nil {
+		return err
+	}
+	return PopulateCache(name, templateURL, unzipped)
+}
+
+func cacheStatus(name string) string {
+	if HasCachedCopy(name) {
+		return "cached"
+	}
+	return "not cached"
+}
@@ -0,0 +1,27 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package template
+
+import "testing"
+
+func TestValidResourceNameRejectsTraversal(t *testing.T) {
+	invalid := []string{"", ".", "..", "../../etc", "foo/../bar", "foo/bar", `foo\bar`}
+	for _, name := range invalid {
+		if err := validResourceName("template", name); err == nil {
+			t.Errorf("validResourceName(%q) = nil, want an error", name)
+		}
+	}
+}
+
+func TestValidResourceNameAcceptsOrdinaryNames(t *testing.T) {
+	valid := []string{"java", "template-java", "my.template"}
+	for _, name := range valid {
+		if err := validResourceName("template", name); err != nil {
+			t.Errorf("validResourceName(%q) = %v, want nil", name, err)
+		}
+	}
+}
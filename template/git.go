@@ -0,0 +1,54 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package template
+
+import "strings"
+
+const gitPrefix = "git+"
+
+// IsGitURL tells whether a template location refers to a git repository
+// (either a "git+<scheme>://" URL or a plain URL ending in ".git", optionally
+// followed by "@<ref>") instead of a downloadable zip archive.
+func IsGitURL(templateURL string) bool {
+	if strings.HasPrefix(templateURL, gitPrefix) {
+		return true
+	}
+	u, _ := splitGitRef(templateURL)
+	return strings.HasSuffix(u, ".git")
+}
+
+// ParseGitURL splits a git template location into the URL to clone and the
+// optional ref (branch, tag or commit SHA) to check out. ref is empty when
+// the location does not specify one, in which case the repo's default branch
+// should be used.
+func ParseGitURL(templateURL string) (cloneURL, ref string) {
+	return splitGitRef(strings.TrimPrefix(templateURL, gitPrefix))
+}
+
+// stripGitRef removes the "git+" prefix and any "@<ref>" suffix so the
+// remainder can be validated as a plain URI.
+func stripGitRef(templateURL string) string {
+	u, _ := splitGitRef(strings.TrimPrefix(templateURL, gitPrefix))
+	return u
+}
+
+// splitGitRef splits a ref off the final path segment of u, e.g.
+// "https://host/repo.git@v1" -> ("https://host/repo.git", "v1") and
+// "https://host/repo@v1.2.0" -> ("https://host/repo", "v1.2.0"). Only the
+// last path segment is inspected so a "user:pass@host" userinfo component
+// earlier in the URL is never mistaken for a ref separator.
+func splitGitRef(u string) (cloneURL, ref string) {
+	lastSlash := strings.LastIndex(u, "/")
+	if lastSlash == -1 {
+		return u, ""
+	}
+	if i := strings.Index(u[lastSlash:], "@"); i != -1 {
+		idx := lastSlash + i
+		return u[:idx], u[idx+1:]
+	}
+	return u, ""
+}
@@ -0,0 +1,274 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+)
+
+const registriesProperties = "template.registries"
+const etagKey = "__etag__"
+const bodyKey = "__body__"
+
+// networkTimeout bounds every outgoing HTTP request this package makes
+// (registry index fetches, signature downloads), so a registry or signing
+// host that accepts a connection but never responds cannot hang a plain
+// `gauge init` forever.
+const networkTimeout = 30 * time.Second
+
+// httpClient is shared by every HTTP call in this package so they all get
+// the same timeout.
+var httpClient = &http.Client{Timeout: networkTimeout}
+
+// registryEntry is one template published by a registry index.
+type registryEntry struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type registries struct {
+	r     map[string]*config.Property
+	names []string
+}
+
+func (r *registries) update(name, url string) {
+	if _, ok := r.r[name]; ok {
+		r.r[name].Value = url
+	} else {
+		r.r[name] = config.NewProperty(name, url, fmt.Sprintf("Template registry '%s'", name))
+		r.names = append(r.names, name)
+	}
+	sort.Strings(r.names)
+}
+
+func (r *registries) remove(name string) bool {
+	if _, ok := r.r[name]; !ok {
+		return false
+	}
+	delete(r.r, name)
+	names := make([]string, 0, len(r.names))
+	for _, n := range r.names {
+		if n != name {
+			names = append(names, n)
+		}
+	}
+	r.names = names
+	return true
+}
+
+func (r *registries) write() error {
+	var buffer strings.Builder
+	buffer.WriteString(fmt.Sprintf("# %s\n", comment))
+	for _, k := range r.names {
+		v := r.r[k]
+		buffer.WriteString(fmt.Sprintf("\n# %s\n%s = %s\n", v.Description, v.Key, v.Value))
+	}
+	return config.Write(buffer.String(), registriesProperties)
+}
+
+func loadRegistries() (*registries, error) {
+	r := &registries{r: map[string]*config.Property{}}
+	configs, err := common.GetGaugeConfigurationFor(registriesProperties)
+	if err != nil {
+		return r, nil
+	}
+	for k, v := range configs {
+		if err := validResourceName("registry", k); err != nil {
+			logger.Debugf(true, "Ignoring invalid template registry entry: %s", err.Error())
+			continue
+		}
+		r.update(k, v)
+	}
+	return r, nil
+}
+
+// RegistryAdd registers a named template registry. A registry index is a
+// JSON document hosted at url listing the templates it publishes.
+func RegistryAdd(name, registryURL string) error {
+	if err := validResourceName("registry", name); err != nil {
+		return err
+	}
+	if _, err := url.ParseRequestURI(registryURL); err != nil {
+		return fmt.Errorf("'%s' is not a valid registry URL", registryURL)
+	}
+	r, err := loadRegistries()
+	if err != nil {
+		return err
+	}
+	r.update(name, registryURL)
+	return r.write()
+}
+
+// RegistryRemove unregisters a template registry by name.
+func RegistryRemove(name string) error {
+	r, err := loadRegistries()
+	if err != nil {
+		return err
+	}
+	if !r.remove(name) {
+		return fmt.Errorf("cannot find a template registry '%s'", name)
+	}
+	return r.write()
+}
+
+// RegistryList returns the configured template registries, in priority order
+// (earlier entries are layered under later ones, same as template.List).
+func RegistryList(machineReadable bool) (string, error) {
+	r, err := loadRegistries()
+	if err != nil {
+		return "", err
+	}
+	var f config.Formatter
+	f = config.TextFormatter{Headers: []string{"Registry Name", "URL"}}
+	if machineReadable {
+		f = config.JsonFormatter{}
+	}
+	var all []config.Property
+	for _, k := range r.names {
+		all = append(all, *r.r[k])
+	}
+	return f.Format(all)
+}
+
+// RegistryRefresh re-fetches every registered registry's index, bypassing
+// the ETag cache.
+func RegistryRefresh() error {
+	r, err := loadRegistries()
+	if err != nil {
+		return err
+	}
+	for _, name := range r.names {
+		if _, err := fetchRegistryIndex(name, r.r[name].Value, true); err != nil {
+			return fmt.Errorf("failed to refresh template registry '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// mergeRegistryTemplates layers every template published by every registered
+// registry on top of t, in registry-priority order. A registry that cannot be
+// reached falls back to its last successfully cached index, if any, so a
+// single unreachable catalog does not break template resolution.
+func mergeRegistryTemplates(t *templates) error {
+	r, err := loadRegistries()
+	if err != nil {
+		return err
+	}
+	for _, name := range r.names {
+		entries, err := fetchRegistryIndex(name, r.r[name].Value, false)
+		if err != nil {
+			logger.Debugf(true, "Failed to fetch template registry '%s': %s", name, err.Error())
+			continue
+		}
+		for _, e := range entries {
+			if err := t.updateFrom(e.Name, e.URL, "registry:"+name, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// registryCacheFile builds the config filename a registry's cached index is
+// stored under. name must already have passed validResourceName (both
+// RegistryAdd and loadRegistries guarantee this for every name reaching
+// here) so it cannot contain a path separator or ".." segment.
+func registryCacheFile(name string) string {
+	return fmt.Sprintf("template.registry.%s.cache", name)
+}
+
+// fetchRegistryIndex downloads the JSON index published at registryURL,
+// honoring an ETag cache under the gauge config dir so an unchanged catalog
+// is not re-downloaded on every gauge invocation. Pass force to bypass the
+// cache and always hit the network.
+func fetchRegistryIndex(name, registryURL string, force bool) ([]registryEntry, error) {
+	cacheFile := registryCacheFile(name)
+	cached, _ := common.GetGaugeConfigurationFor(cacheFile)
+	etag := cached[etagKey]
+	cachedBody, hasCachedBody := unquoteCachedBody(cached[bodyKey])
+
+	req, err := http.NewRequest(http.MethodGet, registryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !force && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if !force && hasCachedBody {
+			return parseRegistryIndex(cachedBody)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		if hasCachedBody {
+			return parseRegistryIndex(cachedBody)
+		}
+		return nil, fmt.Errorf("registry '%s' reported no changes but no cached index was found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry '%s' returned status %s", name, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := parseRegistryIndex(string(data))
+	if err != nil {
+		return nil, err
+	}
+	cacheRegistryIndex(cacheFile, string(data), resp.Header.Get("ETag"))
+	return entries, nil
+}
+
+func unquoteCachedBody(v string) (string, bool) {
+	if v == "" {
+		return "", false
+	}
+	body, err := strconv.Unquote(v)
+	if err != nil {
+		return "", false
+	}
+	return body, true
+}
+
+func cacheRegistryIndex(cacheFile, body, etag string) {
+	var buffer strings.Builder
+	buffer.WriteString(fmt.Sprintf("# %s\n", comment))
+	buffer.WriteString(fmt.Sprintf("\n# Cached registry index\n%s = %s\n", bodyKey, strconv.Quote(body)))
+	if etag != "" {
+		buffer.WriteString(fmt.Sprintf("\n# Cached ETag\n%s = %s\n", etagKey, etag))
+	}
+	if err := config.Write(buffer.String(), cacheFile); err != nil {
+		logger.Debugf(true, "Failed to cache template registry index %s: %s", cacheFile, err.Error())
+	}
+}
+
+func parseRegistryIndex(body string) ([]registryEntry, error) {
+	var entries []registryEntry
+	if err := json.Unmarshal([]byte(body), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse template registry index: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,106 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package template
+
+import (
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+)
+
+func TestParseRegistryIndex(t *testing.T) {
+	body := `[
+		{"name": "java", "url": "https://example.com/java.zip", "description": "Java template", "version": "1.0.0"},
+		{"name": "python", "url": "https://example.com/python.zip", "description": "Python template", "version": "2.0.0"}
+	]`
+	entries, err := parseRegistryIndex(body)
+	if err != nil {
+		t.Fatalf("parseRegistryIndex returned an unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+	if entries[0].Name != "java" || entries[0].URL != "https://example.com/java.zip" {
+		t.Errorf("entries[0] = %+v, want name=java url=https://example.com/java.zip", entries[0])
+	}
+	if entries[1].Name != "python" || entries[1].Version != "2.0.0" {
+		t.Errorf("entries[1] = %+v, want name=python version=2.0.0", entries[1])
+	}
+}
+
+func TestParseRegistryIndexRejectsMalformedJSON(t *testing.T) {
+	if _, err := parseRegistryIndex("not json"); err == nil {
+		t.Error("expected an error for malformed JSON, got nil")
+	}
+}
+
+func TestUnquoteCachedBody(t *testing.T) {
+	body, ok := unquoteCachedBody(`"[{\"name\":\"java\"}]"`)
+	if !ok {
+		t.Fatal("unquoteCachedBody() ok = false, want true")
+	}
+	if body != `[{"name":"java"}]` {
+		t.Errorf("body = %q, want %q", body, `[{"name":"java"}]`)
+	}
+}
+
+func TestUnquoteCachedBodyEmpty(t *testing.T) {
+	if _, ok := unquoteCachedBody(""); ok {
+		t.Error("unquoteCachedBody(\"\") ok = true, want false")
+	}
+}
+
+func TestUnquoteCachedBodyInvalid(t *testing.T) {
+	if _, ok := unquoteCachedBody("not a quoted string"); ok {
+		t.Error("unquoteCachedBody(invalid) ok = true, want false")
+	}
+}
+
+func TestRegistriesUpdateOrdersNamesAlphabetically(t *testing.T) {
+	r := &registries{r: map[string]*config.Property{}}
+	r.update("zebra", "https://example.com/zebra.json")
+	r.update("acme", "https://example.com/acme.json")
+	r.update("mid", "https://example.com/mid.json")
+
+	want := []string{"acme", "mid", "zebra"}
+	if len(r.names) != len(want) {
+		t.Fatalf("names = %v, want %v", r.names, want)
+	}
+	for i, name := range want {
+		if r.names[i] != name {
+			t.Errorf("names[%d] = %q, want %q", i, r.names[i], name)
+		}
+	}
+
+	r.update("acme", "https://example.com/acme-v2.json")
+	if len(r.names) != 3 {
+		t.Errorf("updating an existing registry should not add a new name, got %v", r.names)
+	}
+	if r.r["acme"].Value != "https://example.com/acme-v2.json" {
+		t.Errorf("acme URL = %q, want the updated value", r.r["acme"].Value)
+	}
+}
+
+func TestRegistriesRemove(t *testing.T) {
+	r := &registries{r: map[string]*config.Property{}}
+	r.update("acme", "https://example.com/acme.json")
+	r.update("zebra", "https://example.com/zebra.json")
+
+	if !r.remove("acme") {
+		t.Fatal("remove(\"acme\") = false, want true")
+	}
+	if _, ok := r.r["acme"]; ok {
+		t.Error("acme should have been removed from r.r")
+	}
+	if len(r.names) != 1 || r.names[0] != "zebra" {
+		t.Errorf("names = %v, want [zebra]", r.names)
+	}
+
+	if r.remove("acme") {
+		t.Error("remove(\"acme\") a second time = true, want false")
+	}
+}
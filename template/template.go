@@ -22,9 +22,14 @@ import (
 const comment = `This file contains Gauge template configurations. Do not delete`
 const templateProperties = "template.properties"
 
+const sourceUser = "user"
+const sourceDefault = "default"
+
 type templates struct {
-	t     map[string]*config.Property
-	names []string
+	t      map[string]*config.Property
+	names  []string
+	source map[string]string
+	verify map[string]*templateVerification
 }
 
 func (t *templates) String() (string, error) {
@@ -39,14 +44,30 @@ func (t *templates) String() (string, error) {
 		if err != nil {
 			return "", err
 		}
+		if vr, ok := t.verify[k]; ok {
+			if vr.SHA256 != "" {
+				if _, err := buffer.WriteString(fmt.Sprintf("%s%s = %s\n", k, sha256Suffix, vr.SHA256)); err != nil {
+					return "", err
+				}
+			}
+			if vr.PubKey != "" {
+				if _, err := buffer.WriteString(fmt.Sprintf("%s%s = %s\n", k, pubkeySuffix, vr.PubKey)); err != nil {
+					return "", err
+				}
+			}
+		}
 	}
 	return buffer.String(), nil
 }
 
 func (t *templates) update(k, v string, validate bool) error {
+	return t.updateFrom(k, v, sourceUser, validate)
+}
+
+func (t *templates) updateFrom(k, v, source string, validate bool) error {
 	if validate {
-		if _, err := url.ParseRequestURI(v); err != nil {
-			return fmt.Errorf("Failed to add template '%s'. The template location must be a valid (https) URI", k)
+		if _, err := url.ParseRequestURI(stripGitRef(v)); err != nil {
+			return fmt.Errorf("Failed to add template '%s'. The template location must be a valid (https) URI or a git URL (git+https://... or a URL ending in .git)", k)
 		}
 	}
 	if _, ok := t.t[k]; ok {
@@ -55,6 +76,10 @@ func (t *templates) update(k, v string, validate bool) error {
 		t.t[k] = config.NewProperty(k, v, fmt.Sprintf("Template download information for gauge %s projects", k))
 		t.names = append(t.names, k)
 	}
+	if t.source == nil {
+		t.source = map[string]string{}
+	}
+	t.source[k] = source
 	sort.Strings(t.names)
 	return nil
 }
@@ -91,14 +116,7 @@ func (t *templates) write() error {
 }
 
 func Update(name, value string) error {
-	t, err := mergeTemplates()
-	if err != nil {
-		return err
-	}
-	if err := t.update(name, value, true); err != nil {
-		return err
-	}
-	return t.write()
+	return UpdateWithVerification(name, value, "", "")
 }
 
 func Merge() error {
@@ -131,7 +149,7 @@ func All() (string, error) {
 
 func List(machineReadable bool) (string, error) {
 	var f config.Formatter
-	f = config.TextFormatter{Headers: []string{"Template Name", "Location"}}
+	f = config.TextFormatter{Headers: []string{"Template Name", "Location", "Source (Cache)"}}
 	if machineReadable {
 		f = config.JsonFormatter{}
 	}
@@ -140,8 +158,10 @@ func List(machineReadable bool) (string, error) {
 		return "", err
 	}
 	var all []config.Property
-	for _, v := range t.t {
-		all = append(all, *v)
+	for _, k := range t.names {
+		v := *t.t[k]
+		v.Description = fmt.Sprintf("%s (%s)", t.source[k], cacheStatus(k))
+		all = append(all, v)
 	}
 	return f.Format(all)
 }
@@ -155,7 +175,12 @@ func defaults() *templates {
 		"ruby":   getProperty("template-ruby", "ruby"),
 		"ts":     getProperty("template-ts", "ts"),
 	}
-	return &templates{t: prop, names: getKeys(prop)}
+	names := getKeys(prop)
+	source := map[string]string{}
+	for _, k := range names {
+		source[k] = sourceDefault
+	}
+	return &templates{t: prop, names: names, source: source}
 }
 
 func getKeys(prop map[string]*config.Property) []string {
@@ -169,11 +194,22 @@ func getKeys(prop map[string]*config.Property) []string {
 
 func mergeTemplates() (*templates, error) {
 	t := defaults()
+	if err := mergeRegistryTemplates(t); err != nil {
+		return nil, err
+	}
 	configs, err := common.GetGaugeConfigurationFor(templateProperties)
 	if err != nil {
 		return t, nil
 	}
 	for k, v := range configs {
+		if strings.HasSuffix(k, sha256Suffix) {
+			t.setVerification(strings.TrimSuffix(k, sha256Suffix), v, "")
+			continue
+		}
+		if strings.HasSuffix(k, pubkeySuffix) {
+			t.setVerification(strings.TrimSuffix(k, pubkeySuffix), "", v)
+			continue
+		}
 		if err := t.update(k, v, false); err != nil {
 			return nil, err
 		}
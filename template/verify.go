@@ -0,0 +1,191 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/util"
+	"github.com/jedisct1/go-minisign"
+)
+
+const sha256Suffix = ".sha256"
+const pubkeySuffix = ".pubkey"
+const minisigExtension = ".minisig"
+
+// templateVerification holds the pinned SHA-256 checksum and/or minisign
+// public key used to authenticate a downloaded template archive before it is
+// extracted into a project.
+type templateVerification struct {
+	SHA256 string
+	PubKey string
+}
+
+func (t *templates) setVerification(name, sha256sum, pubkey string) {
+	if t.verify == nil {
+		t.verify = map[string]*templateVerification{}
+	}
+	v, ok := t.verify[name]
+	if !ok {
+		v = &templateVerification{}
+		t.verify[name] = v
+	}
+	if sha256sum != "" {
+		v.SHA256 = sha256sum
+	}
+	if pubkey != "" {
+		v.PubKey = pubkey
+	}
+}
+
+// UpdateWithVerification sets the download location for a named template,
+// the same as Update, and optionally pins a SHA-256 checksum and/or a
+// minisign public key that every future download of this template must
+// satisfy before Gauge copies it into a project. The pins are checked only
+// for templates downloaded as archives; a git template is cloned unchecked.
+func UpdateWithVerification(name, value, sha256sum, pubkey string) error {
+	t, err := mergeTemplates()
+	if err != nil {
+		return err
+	}
+	if err := t.update(name, value, true); err != nil {
+		return err
+	}
+	if sha256sum != "" || pubkey != "" {
+		t.setVerification(name, sha256sum, pubkey)
+	}
+	return t.write()
+}
+
+// Verify downloads the template registered as name and checks it against the
+// SHA-256 checksum and/or minisign public key pinned for it, without
+// initializing a project from it.
+func Verify(name string) error {
+	templateURL, err := Get(name)
+	if err != nil {
+		return err
+	}
+	if IsGitURL(templateURL) {
+		return fmt.Errorf("template '%s' is sourced from git; checksum/signature verification only applies to downloaded archives", name)
+	}
+	if _, v := verificationFor(templateURL); v == nil {
+		return fmt.Errorf("no checksum or public key is pinned for template '%s'; nothing to verify", name)
+	}
+	tempDir := common.GetTempDir()
+	defer util.Remove(tempDir)
+	if _, err := util.DownloadAndUnzip(templateURL, tempDir); err != nil {
+		return fmt.Errorf("failed to download template '%s' for verification: %w", name, err)
+	}
+	archive, err := LocateArchive(tempDir)
+	if err != nil {
+		return err
+	}
+	return VerifyArchive(templateURL, archive)
+}
+
+// LocateArchive finds the zip archive util.DownloadAndUnzip left in dir
+// alongside the directory it unzipped into.
+func LocateArchive(dir string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.zip"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no downloaded archive found in %s", dir)
+	}
+	return matches[0], nil
+}
+
+// VerifyArchive checks a downloaded template archive against the SHA-256
+// checksum and/or minisign public key pinned for templateURL, if any. It is
+// a no-op when no verification has been configured for that template.
+func VerifyArchive(templateURL, archivePath string) error {
+	name, v := verificationFor(templateURL)
+	if v == nil {
+		return nil
+	}
+	data, err := os.ReadFile(archivePath)
+	if err != nil {
+		return err
+	}
+	if v.SHA256 != "" {
+		sum := sha256.Sum256(data)
+		actual := hex.EncodeToString(sum[:])
+		if !strings.EqualFold(actual, v.SHA256) {
+			return fmt.Errorf("SHA-256 checksum mismatch for template '%s': expected %s, got %s", name, v.SHA256, actual)
+		}
+	}
+	if v.PubKey != "" {
+		if err := verifyMinisig(templateURL, data, v.PubKey); err != nil {
+			return fmt.Errorf("signature verification failed for template '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// IsVerificationPinned reports whether a SHA-256 checksum and/or minisign
+// public key has been pinned for templateURL, i.e. whether callers that
+// download this template are required to verify the archive before using it.
+func IsVerificationPinned(templateURL string) bool {
+	_, v := verificationFor(templateURL)
+	return v != nil
+}
+
+func verificationFor(templateURL string) (string, *templateVerification) {
+	t, err := mergeTemplates()
+	if err != nil {
+		return "", nil
+	}
+	for _, k := range t.names {
+		if t.t[k].Value == templateURL {
+			return k, t.verify[k]
+		}
+	}
+	return "", nil
+}
+
+// verifyMinisig fetches the conventional "<url>.minisig" signature for a
+// template archive and verifies it against the pinned minisign public key.
+func verifyMinisig(templateURL string, data []byte, pubkey string) error {
+	sigURL := templateURL + minisigExtension
+	resp, err := httpClient.Get(sigURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signature %s: %w", sigURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch signature %s: status %s", sigURL, resp.Status)
+	}
+	sigBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	pk, err := minisign.NewPublicKey(pubkey)
+	if err != nil {
+		return fmt.Errorf("invalid minisign public key: %w", err)
+	}
+	sig, err := minisign.DecodeSignature(string(sigBytes))
+	if err != nil {
+		return fmt.Errorf("invalid minisign signature: %w", err)
+	}
+	valid, err := pk.Verify(data, sig)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("signature does not match the pinned public key")
+	}
+	return nil
+}
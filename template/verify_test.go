@@ -0,0 +1,36 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocateArchiveFindsDownloadedZip(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "template.zip")
+	if err := os.WriteFile(zipPath, []byte("dummy"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := LocateArchive(dir)
+	if err != nil {
+		t.Fatalf("LocateArchive returned an unexpected error: %v", err)
+	}
+	if archive != zipPath {
+		t.Errorf("archive = %q, want %q", archive, zipPath)
+	}
+}
+
+func TestLocateArchiveErrorsWhenNoZipPresent(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LocateArchive(dir); err == nil {
+		t.Error("expected an error when no archive is present, got nil")
+	}
+}